@@ -0,0 +1,96 @@
+package dropbox // nolint: golint
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// cacheKey identifies a single cached block. Keying on rev means a cache entry is
+// automatically invalidated as soon as the file it was read from changes.
+type cacheKey struct {
+	path       string
+	rev        string
+	blockIndex int64
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	data []byte
+}
+
+// readCache is an LRU cache of downloaded blocks, bounded by total byte size rather than
+// entry count, since blocks aren't all the same size (the last block of a file is shorter).
+type readCache struct {
+	blockSize int64
+	maxBytes  int64
+	usedBytes int64
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	order   *list.List
+
+	hits   uint64
+	misses uint64
+}
+
+func newReadCache(maxBytes, blockSize int64) *readCache {
+	return &readCache{
+		blockSize: blockSize,
+		maxBytes:  maxBytes,
+		entries:   make(map[cacheKey]*list.Element),
+		order:     list.New(),
+	}
+}
+
+func (c *readCache) get(key cacheKey) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	atomic.AddUint64(&c.hits, 1)
+
+	return el.Value.(*cacheEntry).data, true
+}
+
+func (c *readCache) put(key cacheKey, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.usedBytes -= int64(len(el.Value.(*cacheEntry).data))
+		el.Value = &cacheEntry{key: key, data: data}
+		c.order.MoveToFront(el)
+	} else {
+		el := c.order.PushFront(&cacheEntry{key: key, data: data})
+		c.entries[key] = el
+	}
+
+	c.usedBytes += int64(len(data))
+
+	for c.usedBytes > c.maxBytes && c.order.Len() > 0 {
+		back := c.order.Back()
+		entry := back.Value.(*cacheEntry)
+
+		c.usedBytes -= int64(len(entry.data))
+		delete(c.entries, entry.key)
+		c.order.Remove(back)
+	}
+}
+
+// Hits returns the number of cache hits served so far.
+func (c *readCache) Hits() uint64 {
+	return atomic.LoadUint64(&c.hits)
+}
+
+// Misses returns the number of cache misses served so far.
+func (c *readCache) Misses() uint64 {
+	return atomic.LoadUint64(&c.misses)
+}
@@ -0,0 +1,94 @@
+package dropbox // nolint: golint
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReadCacheGetMissThenHitAfterPut(t *testing.T) {
+	c := newReadCache(1024, 64)
+
+	key := cacheKey{path: "/a", rev: "rev1", blockIndex: 0}
+
+	if _, ok := c.get(key); ok {
+		t.Fatal("get() on empty cache = true, want false")
+	}
+
+	c.put(key, []byte("hello"))
+
+	got, ok := c.get(key)
+	if !ok {
+		t.Fatal("get() after put = false, want true")
+	}
+
+	if !bytes.Equal(got, []byte("hello")) {
+		t.Fatalf("get() = %q, want %q", got, "hello")
+	}
+
+	if got, want := c.Hits(), uint64(1); got != want {
+		t.Fatalf("Hits() = %d, want %d", got, want)
+	}
+
+	if got, want := c.Misses(), uint64(1); got != want {
+		t.Fatalf("Misses() = %d, want %d", got, want)
+	}
+}
+
+func TestReadCacheDifferentRevIsAMiss(t *testing.T) {
+	c := newReadCache(1024, 64)
+
+	c.put(cacheKey{path: "/a", rev: "rev1", blockIndex: 0}, []byte("old"))
+
+	if _, ok := c.get(cacheKey{path: "/a", rev: "rev2", blockIndex: 0}); ok {
+		t.Fatal("get() with a different rev = true, want false (stale entry should be invisible)")
+	}
+}
+
+func TestReadCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newReadCache(20, 10)
+
+	keyA := cacheKey{path: "/a", rev: "rev1", blockIndex: 0}
+	keyB := cacheKey{path: "/a", rev: "rev1", blockIndex: 1}
+	keyC := cacheKey{path: "/a", rev: "rev1", blockIndex: 2}
+
+	c.put(keyA, bytes.Repeat([]byte("a"), 10))
+	c.put(keyB, bytes.Repeat([]byte("b"), 10))
+
+	// Touch keyA so keyB becomes the least recently used entry.
+	if _, ok := c.get(keyA); !ok {
+		t.Fatal("get(keyA) = false, want true")
+	}
+
+	// Adding keyC exceeds the 20-byte budget; keyB should be evicted, not keyA.
+	c.put(keyC, bytes.Repeat([]byte("c"), 10))
+
+	if _, ok := c.get(keyB); ok {
+		t.Fatal("get(keyB) after eviction = true, want false")
+	}
+
+	if _, ok := c.get(keyA); !ok {
+		t.Fatal("get(keyA) after eviction = false, want true (it was the most recently used)")
+	}
+
+	if _, ok := c.get(keyC); !ok {
+		t.Fatal("get(keyC) after eviction = false, want true")
+	}
+}
+
+func TestReadCachePutOverwritesExistingKey(t *testing.T) {
+	c := newReadCache(1024, 64)
+
+	key := cacheKey{path: "/a", rev: "rev1", blockIndex: 0}
+
+	c.put(key, []byte("old"))
+	c.put(key, []byte("new"))
+
+	got, ok := c.get(key)
+	if !ok {
+		t.Fatal("get() after overwrite = false, want true")
+	}
+
+	if !bytes.Equal(got, []byte("new")) {
+		t.Fatalf("get() = %q, want %q", got, "new")
+	}
+}
@@ -0,0 +1,78 @@
+package dropbox // nolint: golint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+// contentHashBlockSize is the block size Dropbox's content-hash algorithm splits files into.
+const contentHashBlockSize = 4 * 1024 * 1024
+
+// contentHasher computes Dropbox's content hash incrementally as bytes are written to it:
+// sha256 of each contentHashBlockSize block, then sha256 of the concatenation of those
+// digests. The lowercase hex of that final digest is the value Dropbox reports as
+// FileMetadata.ContentHash.
+type contentHasher struct {
+	overall hash.Hash
+	block   hash.Hash
+	inBlock int
+}
+
+func newContentHasher() *contentHasher {
+	return &contentHasher{
+		overall: sha256.New(),
+		block:   sha256.New(),
+	}
+}
+
+// Write implements io.Writer.
+func (h *contentHasher) Write(p []byte) (int, error) {
+	total := len(p)
+
+	for len(p) > 0 {
+		room := contentHashBlockSize - h.inBlock
+		if room > len(p) {
+			room = len(p)
+		}
+
+		h.block.Write(p[:room])
+		h.inBlock += room
+		p = p[room:]
+
+		if h.inBlock == contentHashBlockSize {
+			h.overall.Write(h.block.Sum(nil))
+			h.block = sha256.New()
+			h.inBlock = 0
+		}
+	}
+
+	return total, nil
+}
+
+// Sum returns the lowercase hex content hash of everything written so far.
+func (h *contentHasher) Sum() string {
+	if h.inBlock > 0 {
+		h.overall.Write(h.block.Sum(nil))
+		h.block = sha256.New()
+		h.inBlock = 0
+	}
+
+	return hex.EncodeToString(h.overall.Sum(nil))
+}
+
+// hashingReadCloser feeds every byte read through hasher before returning it to the caller.
+type hashingReadCloser struct {
+	io.ReadCloser
+	hasher *contentHasher
+}
+
+func (h *hashingReadCloser) Read(p []byte) (int, error) {
+	n, err := h.ReadCloser.Read(p)
+	if n > 0 {
+		h.hasher.Write(p[:n])
+	}
+
+	return n, err
+}
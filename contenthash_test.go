@@ -0,0 +1,86 @@
+package dropbox // nolint: golint
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestContentHasherKnownHashes(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{
+			name: "empty",
+			data: nil,
+			want: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		{
+			name: "abc",
+			data: []byte("abc"),
+			want: "4f8b42c22dd3729b519ba6f68d2da7cc5b2d606d05daed5ad5128cc03e6c6358",
+		},
+		{
+			name: "multi-block",
+			data: bytes.Repeat([]byte("x"), contentHashBlockSize+10),
+			want: "c536bbab84521763869f6df63521fbe2227f6b9d519107aecb182b608bb301c9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newContentHasher()
+
+			if _, err := h.Write(tt.data); err != nil {
+				t.Fatalf("Write() = %v, want nil", err)
+			}
+
+			if got := h.Sum(); got != tt.want {
+				t.Fatalf("Sum() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentHasherWriteInSeveralCalls(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), contentHashBlockSize+10)
+
+	whole := newContentHasher()
+	if _, err := whole.Write(data); err != nil {
+		t.Fatalf("Write() = %v, want nil", err)
+	}
+
+	piecemeal := newContentHasher()
+	for _, chunk := range [][]byte{data[:1], data[1:contentHashBlockSize], data[contentHashBlockSize:]} {
+		if _, err := piecemeal.Write(chunk); err != nil {
+			t.Fatalf("Write() = %v, want nil", err)
+		}
+	}
+
+	if got, want := piecemeal.Sum(), whole.Sum(); got != want {
+		t.Fatalf("Sum() across several Write calls = %s, want %s (same as one Write)", got, want)
+	}
+}
+
+func TestHashingReadCloserHashesAsItReads(t *testing.T) {
+	data := []byte("the quick brown fox")
+
+	want := newContentHasher()
+	if _, err := want.Write(data); err != nil {
+		t.Fatalf("Write() = %v, want nil", err)
+	}
+
+	h := newContentHasher()
+	rc := &hashingReadCloser{ReadCloser: io.NopCloser(strings.NewReader(string(data))), hasher: h}
+
+	if _, err := io.Copy(io.Discard, rc); err != nil {
+		t.Fatalf("read through hashingReadCloser: %v", err)
+	}
+
+	if got, want := h.Sum(), want.Sum(); got != want {
+		t.Fatalf("hashingReadCloser Sum() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+package dropbox // nolint: golint
+
+import (
+	"context"
+	"io"
+)
+
+// runContext runs fn in a goroutine and returns its error, or ctx.Err() if ctx is done first.
+// The underlying Dropbox SDK doesn't accept a context itself, so a timed-out or cancelled
+// call keeps running in the background; callers just stop waiting on it.
+func runContext(ctx context.Context, fn func() error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// watchContext closes closer as soon as ctx is done, so a blocked streaming read aborts
+// instead of hanging. The returned func must be called once the stream is closed normally, to
+// stop the watcher goroutine from leaking.
+func watchContext(ctx context.Context, closer io.Closer) func() {
+	stop := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = closer.Close()
+		case <-stop:
+		}
+	}()
+
+	return func() { close(stop) }
+}
+
+// boundContext derives a context from ctx that additionally expires after fs.DefaultTimeout,
+// if one is configured and ctx carries no deadline of its own. The returned cancel func must
+// always be called.
+func (fs *Fs) boundContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, hasDeadline := ctx.Deadline(); hasDeadline || fs.DefaultTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, fs.DefaultTimeout)
+}
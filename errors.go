@@ -0,0 +1,18 @@
+package dropbox // nolint: golint
+
+import "errors"
+
+var (
+	// ErrNotSupported is returned for operations that the Dropbox backend can't perform.
+	ErrNotSupported = errors.New("not supported")
+
+	// ErrAlreadyOpened is returned when trying to open a file that already has an active stream.
+	ErrAlreadyOpened = errors.New("already opened")
+
+	// ErrInvalidSeek is returned when a seek would land before the start of the file.
+	ErrInvalidSeek = errors.New("invalid seek")
+
+	// ErrContentHashMismatch is returned when the locally-computed Dropbox content hash of a
+	// streamed upload or download doesn't match the one Dropbox reports for the file.
+	ErrContentHashMismatch = errors.New("content hash mismatch")
+)
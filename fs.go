@@ -0,0 +1,386 @@
+package dropbox // nolint: golint
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dropbox/dropbox-sdk-go-unofficial/dropbox"
+	"github.com/dropbox/dropbox-sdk-go-unofficial/dropbox/files"
+	"github.com/spf13/afero"
+)
+
+const (
+	// defaultChunkSize is the size of each upload-session chunk when none is configured.
+	defaultChunkSize = 8 * 1024 * 1024
+
+	// defaultMaxRetries is the number of retries attempted per Dropbox RPC when none is configured.
+	defaultMaxRetries = 5
+
+	// defaultInitialBackoff is the delay before the first retry of a Dropbox RPC.
+	defaultInitialBackoff = 500 * time.Millisecond
+)
+
+// Fs is an afero.Fs implementation backed by a Dropbox account.
+type Fs struct {
+	files        files.Client
+	dirListLimit int
+
+	// ChunkSize is the size, in bytes, of each upload-session chunk.
+	ChunkSize int
+
+	// MaxRetries is the number of times a failed RPC is retried before giving up. Zero means
+	// unlimited retries. Only read once, by New, to build the default Pacer: set it via
+	// WithMaxRetries, or mutating it afterwards has no effect unless Pacer is rebuilt manually.
+	MaxRetries int
+
+	// InitialBackoff is the pacer's starting backoff between retries; it doubles on every
+	// subsequent attempt, up to MaxBackoff. Only read once, by New, to build the default Pacer:
+	// set it via WithInitialBackoff, or mutating it afterwards has no effect unless Pacer is
+	// rebuilt manually.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the pacer's backoff between retries. Only read once, by New, to build the
+	// default Pacer: set it via WithMaxBackoff, or mutating it afterwards has no effect unless
+	// Pacer is rebuilt manually.
+	MaxBackoff time.Duration
+
+	// Pacer retries every Dropbox RPC this package makes, backing off adaptively on rate
+	// limits and transient errors. It defaults to the package's own adaptive pacer, built
+	// from InitialBackoff/MaxBackoff/MaxRetries, but can be swapped out (e.g. in tests) for a
+	// deterministic implementation.
+	Pacer Pacer
+
+	// VerifyDownload makes File.Read compute the content hash of a download as it streams
+	// and compare it against Dropbox's reported hash once the file has been fully read. Only a
+	// stream read from offset 0 is hashed: after a Seek to any other offset, no hash is
+	// compared. It isn't supported together with a read cache (see WithReadCache): Open/OpenFile
+	// return ErrNotSupported instead of silently skipping verification.
+	VerifyDownload bool
+
+	// DefaultTimeout bounds how long any Context-aware call waits on the Dropbox API before
+	// giving up, when the caller's own context carries no deadline. Zero means no bound.
+	DefaultTimeout time.Duration
+
+	// MaxScratchSize caps how much of a file Truncate and WriteAt will buffer in memory in
+	// order to download-modify-reupload it. Above this size they return ErrNotSupported
+	// instead of buffering an unbounded amount of data. Zero means no cap.
+	MaxScratchSize int64
+
+	readCache *readCache
+}
+
+// Option configures an Fs constructed by New.
+type Option func(*Fs)
+
+// WithReadCache enables an LRU cache of downloaded blocks, so seek-heavy readers (like
+// archive/zip, which seeks to the central directory and back) don't re-issue a ranged
+// Download on every Seek. totalBytes bounds the cache's overall memory footprint; blockSize
+// controls the granularity of the cached ranges. Cache entries key on the file's Rev, so they
+// invalidate automatically when the file changes. Not supported together with VerifyDownload.
+func WithReadCache(totalBytes, blockSize int) Option {
+	return func(fs *Fs) {
+		fs.readCache = newReadCache(int64(totalBytes), int64(blockSize))
+	}
+}
+
+// WithDefaultTimeout bounds how long any Context-aware call waits on the Dropbox API, for
+// callers (e.g. unattended jobs) whose own context carries no deadline.
+func WithDefaultTimeout(timeout time.Duration) Option {
+	return func(fs *Fs) {
+		fs.DefaultTimeout = timeout
+	}
+}
+
+// WithMaxScratchSize caps how much of a file Truncate and WriteAt will buffer in memory to
+// download-modify-reupload it; above that size they fail with ErrNotSupported instead.
+func WithMaxScratchSize(maxBytes int64) Option {
+	return func(fs *Fs) {
+		fs.MaxScratchSize = maxBytes
+	}
+}
+
+// WithPacer overrides the default adaptive-backoff Pacer, e.g. with a deterministic
+// implementation in tests.
+func WithPacer(p Pacer) Option {
+	return func(fs *Fs) {
+		fs.Pacer = p
+	}
+}
+
+// WithMaxRetries sets the number of times a failed RPC is retried before giving up, overriding
+// defaultMaxRetries. Zero means unlimited retries. Has no effect if WithPacer is also given.
+func WithMaxRetries(maxRetries int) Option {
+	return func(fs *Fs) {
+		fs.MaxRetries = maxRetries
+	}
+}
+
+// WithInitialBackoff sets the pacer's starting backoff between retries, overriding
+// defaultInitialBackoff. Has no effect if WithPacer is also given.
+func WithInitialBackoff(d time.Duration) Option {
+	return func(fs *Fs) {
+		fs.InitialBackoff = d
+	}
+}
+
+// WithMaxBackoff caps the pacer's backoff between retries, overriding defaultPacerMaxSleep. Has
+// no effect if WithPacer is also given.
+func WithMaxBackoff(d time.Duration) Option {
+	return func(fs *Fs) {
+		fs.MaxBackoff = d
+	}
+}
+
+// New creates a new Fs backed by the Dropbox account identified by the given API token.
+func New(token string, opts ...Option) *Fs {
+	config := dropbox.Config{Token: token}
+
+	fs := &Fs{
+		files:          files.New(config),
+		ChunkSize:      defaultChunkSize,
+		MaxRetries:     defaultMaxRetries,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultPacerMaxSleep,
+	}
+
+	for _, opt := range opts {
+		opt(fs)
+	}
+
+	if fs.Pacer == nil {
+		fs.Pacer = NewPacer(fs.InitialBackoff, fs.MaxBackoff, fs.MaxRetries)
+	}
+
+	return fs
+}
+
+// CacheHits returns the number of read-cache hits served so far, or 0 if no read cache is
+// configured.
+func (fs *Fs) CacheHits() uint64 {
+	if fs.readCache == nil {
+		return 0
+	}
+
+	return fs.readCache.Hits()
+}
+
+// CacheMisses returns the number of read-cache misses served so far, or 0 if no read cache is
+// configured.
+func (fs *Fs) CacheMisses() uint64 {
+	if fs.readCache == nil {
+		return 0
+	}
+
+	return fs.readCache.Misses()
+}
+
+// Retries returns the number of times the Pacer has retried a Dropbox RPC so far.
+func (fs *Fs) Retries() uint64 {
+	return fs.Pacer.Retries()
+}
+
+// Name returns the name of this Fs.
+func (fs *Fs) Name() string {
+	return "dropbox"
+}
+
+// CreateContext creates a file in the filesystem, returning the file and an error, if any
+// happens. The upload doesn't actually start until the first Write, WriteAt or Truncate call on
+// the returned File disambiguates whether it's a streamed write or a download-modify-reupload;
+// cancelling ctx, or fs.DefaultTimeout elapsing, aborts a streamed write's upload or a
+// download-modify-reupload's download. The re-upload that WriteAt (on Close) and Truncate
+// perform is not bound by ctx and runs to completion.
+func (fs *Fs) CreateContext(ctx context.Context, name string) (afero.File, error) {
+	ctx, cancel := fs.boundContext(ctx)
+
+	file := newFile(fs, name)
+	file.ctxCancel = cancel
+	file.prepareWrite(ctx)
+
+	return file, nil
+}
+
+// Create creates a file in the filesystem, returning the file and an error, if any happens.
+func (fs *Fs) Create(name string) (afero.File, error) {
+	return fs.CreateContext(context.Background(), name)
+}
+
+// Mkdir creates a directory.
+func (fs *Fs) Mkdir(name string, _ os.FileMode) error {
+	err := fs.Pacer.Call(func() error {
+		_, err := fs.files.CreateFolderV2(&files.CreateFolderArg{Path: name})
+
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("couldn't create folder: %w", err)
+	}
+
+	return nil
+}
+
+// MkdirAll creates a directory and all its parents, if needed.
+func (fs *Fs) MkdirAll(path string, perm os.FileMode) error {
+	return fs.Mkdir(path, perm)
+}
+
+// OpenContext opens a file for reading. Cancelling ctx aborts the in-flight download.
+func (fs *Fs) OpenContext(ctx context.Context, name string) (afero.File, error) {
+	return fs.OpenFileContext(ctx, name, os.O_RDONLY, 0)
+}
+
+// Open opens a file for reading.
+func (fs *Fs) Open(name string) (afero.File, error) {
+	return fs.OpenContext(context.Background(), name)
+}
+
+// OpenFileContext opens a file using the given flags. Cancelling ctx, or fs.DefaultTimeout
+// elapsing, aborts the in-flight upload or download. For a file opened for writing, the upload
+// doesn't actually start until the first Write, WriteAt or Truncate call disambiguates whether
+// it's a streamed write or a download-modify-reupload. The re-upload that WriteAt (on Close) and
+// Truncate perform, and the ranged downloads a read cache makes on demand, are not bound by ctx.
+func (fs *Fs) OpenFileContext(ctx context.Context, name string, flag int, _ os.FileMode) (afero.File, error) {
+	ctx, cancel := fs.boundContext(ctx)
+
+	file := newFile(fs, name)
+	file.ctxCancel = cancel
+
+	if flag&(os.O_WRONLY|os.O_RDWR) != 0 {
+		file.prepareWrite(ctx)
+
+		return file, nil
+	}
+
+	if err := file.openReadStream(ctx, 0); err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// OpenFile opens a file using the given flags.
+func (fs *Fs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	return fs.OpenFileContext(context.Background(), name, flag, perm)
+}
+
+// RemoveContext removes a file, aborting if ctx is done before the call completes.
+func (fs *Fs) RemoveContext(ctx context.Context, name string) error {
+	ctx, cancel := fs.boundContext(ctx)
+	defer cancel()
+
+	err := runContext(ctx, func() error {
+		return fs.Pacer.Call(func() error {
+			_, err := fs.files.DeleteV2(&files.DeleteArg{Path: name})
+
+			return err
+		})
+	})
+
+	if err != nil {
+		return fmt.Errorf("couldn't delete: %w", err)
+	}
+
+	return nil
+}
+
+// Remove removes a file.
+func (fs *Fs) Remove(name string) error {
+	return fs.RemoveContext(context.Background(), name)
+}
+
+// RemoveAll removes a file and any children it has.
+func (fs *Fs) RemoveAll(path string) error {
+	return fs.Remove(path)
+}
+
+// Rename renames a file.
+func (fs *Fs) Rename(oldname, newname string) error {
+	arg := &files.RelocationArg{
+		RelocationPath: files.RelocationPath{FromPath: oldname, ToPath: newname},
+	}
+
+	err := fs.Pacer.Call(func() error {
+		_, err := fs.files.MoveV2(arg)
+
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("couldn't rename: %w", err)
+	}
+
+	return nil
+}
+
+// StatContext returns the FileInfo for the given path, aborting if ctx is done before the
+// call completes.
+func (fs *Fs) StatContext(ctx context.Context, name string) (os.FileInfo, error) {
+	ctx, cancel := fs.boundContext(ctx)
+	defer cancel()
+
+	var meta files.IsMetadata
+
+	err := runContext(ctx, func() error {
+		return fs.Pacer.Call(func() error {
+			m, err := fs.files.GetMetadata(&files.GetMetadataArg{Path: name})
+			if err != nil {
+				return err
+			}
+
+			meta = m
+
+			return nil
+		})
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch file info: %w", err)
+	}
+
+	return newFileInfo(meta), nil
+}
+
+// Stat returns the FileInfo for the given path.
+func (fs *Fs) Stat(name string) (os.FileInfo, error) {
+	return fs.StatContext(context.Background(), name)
+}
+
+func (fs *Fs) stat(name string) (os.FileInfo, error) {
+	return fs.StatContext(context.Background(), name)
+}
+
+// ContentHash returns the content hash Dropbox computed server-side for name, as reported by
+// GetMetadata, so callers can compare it against a locally-computed hash of the same bytes.
+func (fs *Fs) ContentHash(name string) (string, error) {
+	info, err := fs.stat(name)
+	if err != nil {
+		return "", err
+	}
+
+	hash, ok := info.(*FileInfo).ContentHash()
+	if !ok {
+		return "", fmt.Errorf("%s: %w", name, ErrNotSupported)
+	}
+
+	return hash, nil
+}
+
+// Chmod isn't supported by dropbox.
+func (fs *Fs) Chmod(_ string, _ os.FileMode) error {
+	return ErrNotSupported
+}
+
+// Chtimes isn't supported by dropbox.
+func (fs *Fs) Chtimes(_ string, _ time.Time, _ time.Time) error {
+	return ErrNotSupported
+}
+
+// Chown isn't supported by dropbox.
+func (fs *Fs) Chown(_ string, _ int, _ int) error {
+	return ErrNotSupported
+}
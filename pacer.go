@@ -0,0 +1,134 @@
+package dropbox // nolint: golint
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dropbox/dropbox-sdk-go-unofficial/dropbox/auth"
+)
+
+const (
+	// defaultPacerMinSleep is the pacer's starting backoff when none is configured.
+	defaultPacerMinSleep = 10 * time.Millisecond
+
+	// defaultPacerMaxSleep caps the pacer's backoff when none is configured.
+	defaultPacerMaxSleep = 2 * time.Second
+)
+
+// Pacer paces and retries Dropbox API calls, backing off adaptively on rate limits and
+// transient errors so a burst of requests doesn't trip Dropbox's rate limiter repeatedly.
+// It's an interface so tests can inject a deterministic implementation.
+type Pacer interface {
+	// Call invokes fn, retrying it with backoff while it keeps failing with a retryable
+	// error, and returns its final result.
+	Call(fn func() error) error
+
+	// Retries returns the number of retries performed so far.
+	Retries() uint64
+}
+
+// pacer is the default Pacer: adaptive exponential backoff that doubles its sleep on every
+// retry, resets to MinSleep as soon as a call succeeds, and honors any Retry-After hint on a
+// rate-limit error.
+type pacer struct {
+	minSleep   time.Duration
+	maxSleep   time.Duration
+	maxRetries int
+
+	sleep   int64 // current backoff, as a time.Duration, accessed atomically
+	retries uint64
+}
+
+// NewPacer creates the default Pacer. minSleep/maxSleep fall back to 10ms/2s when zero.
+// maxRetries caps how many times a single Call retries before giving up; zero means unlimited.
+func NewPacer(minSleep, maxSleep time.Duration, maxRetries int) Pacer {
+	if minSleep <= 0 {
+		minSleep = defaultPacerMinSleep
+	}
+
+	if maxSleep <= 0 {
+		maxSleep = defaultPacerMaxSleep
+	}
+
+	p := &pacer{minSleep: minSleep, maxSleep: maxSleep, maxRetries: maxRetries}
+	atomic.StoreInt64(&p.sleep, int64(minSleep))
+
+	return p
+}
+
+func (p *pacer) Call(fn func() error) error {
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		if err = fn(); err == nil {
+			atomic.StoreInt64(&p.sleep, int64(p.minSleep))
+
+			return nil
+		}
+
+		if !isRetryableErr(err) || (p.maxRetries > 0 && attempt >= p.maxRetries) {
+			return err
+		}
+
+		atomic.AddUint64(&p.retries, 1)
+		time.Sleep(p.nextSleep(err))
+	}
+}
+
+func (p *pacer) Retries() uint64 {
+	return atomic.LoadUint64(&p.retries)
+}
+
+// nextSleep returns how long to wait before the next attempt, honoring any Retry-After hint
+// on err, and doubles the pacer's baseline backoff for the attempt after that.
+func (p *pacer) nextSleep(err error) time.Duration {
+	cur := time.Duration(atomic.LoadInt64(&p.sleep))
+
+	next := cur * 2
+	if next > p.maxSleep {
+		next = p.maxSleep
+	}
+
+	atomic.StoreInt64(&p.sleep, int64(next))
+
+	return retryAfterHint(err, cur)
+}
+
+// isRetryableErr reports whether err is worth retrying: a network error, a 5xx, or one of
+// Dropbox's rate-limit responses (too_many_requests / too_many_write_operations). Permanent
+// errors, such as a bad path or invalid auth, are not retryable.
+func isRetryableErr(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var rateLimitErr auth.RateLimitAPIError
+	if errors.As(err, &rateLimitErr) {
+		return true
+	}
+
+	msg := err.Error()
+
+	return strings.Contains(msg, "too_many_write_operations") ||
+		strings.Contains(msg, "too_many_requests") ||
+		strings.Contains(msg, "internal_server_error") ||
+		strings.Contains(msg, "HTTP 5")
+}
+
+// retryAfterHint returns the delay err asks us to wait, honoring the RetryAfter field of
+// Dropbox's auth.RateLimitAPIError, falling back to the given backoff otherwise. The SDK
+// doesn't expose the raw *http.Response, so there's no separate HTTP Retry-After header to
+// check here; RateLimitAPIError.RateLimitError.RetryAfter is the only hint it surfaces.
+func retryAfterHint(err error, fallback time.Duration) time.Duration {
+	var rateLimitErr auth.RateLimitAPIError
+
+	if errors.As(err, &rateLimitErr) && rateLimitErr.RateLimitError != nil && rateLimitErr.RateLimitError.RetryAfter > 0 {
+		return time.Duration(rateLimitErr.RateLimitError.RetryAfter) * time.Second
+	}
+
+	return fallback
+}
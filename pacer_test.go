@@ -0,0 +1,95 @@
+package dropbox // nolint: golint
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dropbox/dropbox-sdk-go-unofficial/dropbox/auth"
+)
+
+func TestPacerCallRetriesThenSucceeds(t *testing.T) {
+	p := NewPacer(time.Millisecond, 4*time.Millisecond, 5)
+
+	attempts := 0
+	err := p.Call(func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("too_many_requests")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Call() = %v, want nil", err)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+
+	if got := p.Retries(); got != 2 {
+		t.Fatalf("Retries() = %d, want 2", got)
+	}
+}
+
+func TestPacerCallGivesUpOnPermanentError(t *testing.T) {
+	p := NewPacer(time.Millisecond, 4*time.Millisecond, 5)
+
+	wantErr := errors.New("path/not_found")
+
+	attempts := 0
+	err := p.Call(func() error {
+		attempts++
+
+		return wantErr
+	})
+
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Call() = %v, want %v", err, wantErr)
+	}
+
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1 (a permanent error shouldn't be retried)", attempts)
+	}
+}
+
+func TestPacerCallStopsAtMaxRetries(t *testing.T) {
+	p := NewPacer(time.Millisecond, 4*time.Millisecond, 2)
+
+	attempts := 0
+	err := p.Call(func() error {
+		attempts++
+
+		return errors.New("too_many_requests")
+	})
+
+	if err == nil {
+		t.Fatal("Call() = nil, want an error once retries are exhausted")
+	}
+
+	if attempts != 3 { // the initial attempt, plus 2 retries
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+
+	if got := p.Retries(); got != 2 {
+		t.Fatalf("Retries() = %d, want 2", got)
+	}
+}
+
+func TestRetryAfterHintHonorsRateLimitError(t *testing.T) {
+	err := auth.RateLimitAPIError{RateLimitError: &auth.RateLimitError{RetryAfter: 5}}
+
+	got := retryAfterHint(err, 100*time.Millisecond)
+	if want := 5 * time.Second; got != want {
+		t.Fatalf("retryAfterHint() = %v, want %v", got, want)
+	}
+}
+
+func TestRetryAfterHintFallsBackWithoutHint(t *testing.T) {
+	got := retryAfterHint(errors.New("boom"), 250*time.Millisecond)
+	if want := 250 * time.Millisecond; got != want {
+		t.Fatalf("retryAfterHint() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,52 @@
+package dropbox // nolint: golint
+
+// overlaySpan is one random-access write waiting to be merged onto a file's baseline content.
+type overlaySpan struct {
+	offset int64
+	data   []byte
+}
+
+// writeOverlay buffers WriteAt calls keyed by offset, since Dropbox has no true
+// random-access write API. It's merged onto a downloaded baseline when the file is closed.
+type writeOverlay struct {
+	spans []overlaySpan
+}
+
+func (o *writeOverlay) writeAt(p []byte, off int64) {
+	data := make([]byte, len(p))
+	copy(data, p)
+
+	o.spans = append(o.spans, overlaySpan{offset: off, data: data})
+}
+
+// size returns the highest offset touched by the overlay, i.e. the minimum length the result
+// of apply must have.
+func (o *writeOverlay) size() int64 {
+	var max int64
+
+	for _, s := range o.spans {
+		if end := s.offset + int64(len(s.data)); end > max {
+			max = end
+		}
+	}
+
+	return max
+}
+
+// apply merges the overlay onto base, in the order the writes were made (so a later write
+// wins on overlap), growing base with zero bytes if a span extends past its current length.
+func (o *writeOverlay) apply(base []byte) []byte {
+	if need := o.size(); int64(len(base)) < need {
+		grown := make([]byte, need)
+		copy(grown, base)
+		base = grown
+	}
+
+	// o.spans is already in insertion order: apply it as-is so a later write overwrites an
+	// earlier one on overlap, rather than re-sorting by offset and losing that ordering.
+	for _, s := range o.spans {
+		copy(base[s.offset:], s.data)
+	}
+
+	return base
+}
@@ -0,0 +1,40 @@
+package dropbox // nolint: golint
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteOverlayApplyLaterWriteWinsOnOverlap(t *testing.T) {
+	o := &writeOverlay{}
+	o.writeAt([]byte("ZZZZZZZZZZ"), 5) // offsets 5-14
+	o.writeAt([]byte("AAAAAAAAAA"), 0) // offsets 0-9, written after: should win on the 5-9 overlap
+
+	got := o.apply(nil)
+	want := []byte("AAAAAAAAAAZZZZZ")
+
+	if !bytes.Equal(got, want) {
+		t.Fatalf("apply() = %q, want %q", got, want)
+	}
+}
+
+func TestWriteOverlaySizeIsHighestOffsetTouched(t *testing.T) {
+	o := &writeOverlay{}
+	o.writeAt([]byte("abc"), 10)
+
+	if got, want := o.size(), int64(13); got != want {
+		t.Fatalf("size() = %d, want %d", got, want)
+	}
+}
+
+func TestWriteOverlayApplyGrowsBaseWithZeros(t *testing.T) {
+	o := &writeOverlay{}
+	o.writeAt([]byte("hi"), 3)
+
+	got := o.apply(nil)
+	want := []byte{0, 0, 0, 'h', 'i'}
+
+	if !bytes.Equal(got, want) {
+		t.Fatalf("apply() = %q, want %q", got, want)
+	}
+}